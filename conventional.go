@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// allowedCommitTypes are the types accepted by the Conventional Commits spec
+// as commonly extended (build/ci on top of the core Angular set).
+var allowedCommitTypes = []string{
+	"feat", "fix", "docs", "style", "refactor", "perf", "test", "build", "ci", "chore", "revert",
+}
+
+// commitTypeAliases maps common misspellings/synonyms the model tends to
+// produce onto the type the repo actually accepts, so a bad type doesn't
+// always require a round trip back to the provider.
+var commitTypeAliases = map[string]string{
+	"feature":       "feat",
+	"bugfix":        "fix",
+	"documentation": "docs",
+	"tests":         "test",
+	"testing":       "test",
+	"chores":        "chore",
+}
+
+var conventionalHeaderRe = regexp.MustCompile(`^([a-zA-Z]+)(\(([^)]+)\))?(!)?: (.+)$`)
+var footerRe = regexp.MustCompile(`^(BREAKING CHANGE|[A-Za-z-]+): .+$`)
+
+// validateConventionalCommit checks a generated message against the
+// Conventional Commits spec and returns a human-readable problem for each
+// violation found, or nil if the message is valid.
+func validateConventionalCommit(message string) []string {
+	lines := strings.Split(strings.TrimSpace(message), "\n")
+	subject := lines[0]
+
+	m := conventionalHeaderRe.FindStringSubmatch(subject)
+	if m == nil {
+		return []string{"subject line must match 'type(scope)!: description'"}
+	}
+
+	var problems []string
+	if !isAllowedCommitType(m[1]) {
+		problems = append(problems, fmt.Sprintf("unknown commit type %q", m[1]))
+	}
+	if len(subject) > 72 {
+		problems = append(problems, "subject line longer than 72 characters")
+	}
+	if strings.TrimSpace(m[5]) == "" {
+		problems = append(problems, "description is empty")
+	}
+	if m[4] == "!" && !hasBreakingChangeFooter(lines[1:]) {
+		problems = append(problems, "breaking change (!) requires a 'BREAKING CHANGE:' footer")
+	}
+
+	return problems
+}
+
+// hasBreakingChangeFooter reports whether the commit body contains a
+// "BREAKING CHANGE: ..." trailer, required by the spec whenever the subject
+// carries a "!" breaking marker.
+func hasBreakingChangeFooter(bodyLines []string) bool {
+	for _, line := range bodyLines {
+		if footerRe.MatchString(line) && strings.HasPrefix(line, "BREAKING CHANGE:") {
+			return true
+		}
+	}
+	return false
+}
+
+func isAllowedCommitType(t string) bool {
+	for _, allowed := range allowedCommitTypes {
+		if t == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// autocorrectConventionalCommit deterministically repairs the mechanical
+// parts of a malformed header (missing "type: " prefix, an aliased type
+// name) without involving the model again. It reports ok=false whenever the
+// candidate it produces still has unresolved problems (e.g. an over-long
+// subject or an empty description), so the caller falls back to
+// re-prompting instead of treating a partial fix as done.
+func autocorrectConventionalCommit(message string) (fixed string, ok bool) {
+	parts := strings.SplitN(strings.TrimSpace(message), "\n", 2)
+	subject := parts[0]
+	rest := ""
+	if len(parts) > 1 {
+		rest = "\n" + parts[1]
+	}
+
+	candidate := message
+	m := conventionalHeaderRe.FindStringSubmatch(subject)
+	if m == nil {
+		candidate = fmt.Sprintf("chore: %s%s", strings.TrimSpace(subject), rest)
+	} else if !isAllowedCommitType(m[1]) {
+		alias, known := commitTypeAliases[strings.ToLower(m[1])]
+		if !known {
+			return "", false
+		}
+
+		scope := ""
+		if m[3] != "" {
+			scope = "(" + m[3] + ")"
+		}
+		candidate = fmt.Sprintf("%s%s%s: %s%s", alias, scope, m[4], strings.TrimSpace(m[5]), rest)
+	}
+
+	if len(validateConventionalCommit(candidate)) > 0 {
+		return "", false
+	}
+	return candidate, true
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateConventionalCommit(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		wantErr bool
+	}{
+		{"valid", "feat(auth): add login flow", false},
+		{"valid with body", "fix: handle nil pointer\n\nMore detail here.", false},
+		{"missing prefix", "add login flow", true},
+		{"unknown type", "wip: add login flow", true},
+		{"too long", "feat: " + strings.Repeat("x", 80), true},
+		{"empty description", "feat: ", true},
+		{"breaking without footer", "feat!: change API shape", true},
+		{"breaking with footer", "feat!: change API shape\n\nBREAKING CHANGE: old API removed", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems := validateConventionalCommit(tt.message)
+			if got := len(problems) > 0; got != tt.wantErr {
+				t.Errorf("validateConventionalCommit(%q) = %v, wantErr %v", tt.message, problems, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAutocorrectConventionalCommit(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		wantOK  bool
+	}{
+		{"missing prefix gets chore prefix", "add login flow", true},
+		{"known alias gets remapped", "feature: add login flow", true},
+		{"unknown alias falls through", "wip: add login flow", false},
+		{"too-long subject falls through to re-prompt", "feat: " + strings.Repeat("x", 80), false},
+		{"empty description falls through to re-prompt", "feat:  \nsome body text", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixed, ok := autocorrectConventionalCommit(tt.message)
+			if ok != tt.wantOK {
+				t.Fatalf("autocorrectConventionalCommit(%q) ok = %v, want %v (fixed=%q)", tt.message, ok, tt.wantOK, fixed)
+			}
+			if ok && len(validateConventionalCommit(fixed)) > 0 {
+				t.Errorf("autocorrectConventionalCommit(%q) returned still-invalid message %q", tt.message, fixed)
+			}
+		})
+	}
+}
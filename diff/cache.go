@@ -0,0 +1,42 @@
+package diff
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// cacheDir returns ~/.easy-commits-cache/, creating it if needed.
+func cacheDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(home, ".easy-commits-cache")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// CachedSummary returns a previously computed per-file summary for blobKey,
+// if one exists, so re-running on the same diff skips the map step.
+func CachedSummary(blobKey string) (string, bool) {
+	dir, err := cacheDir()
+	if err != nil {
+		return "", false
+	}
+	data, err := os.ReadFile(filepath.Join(dir, blobKey))
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+// StoreSummary persists a per-file summary keyed by blobKey.
+func StoreSummary(blobKey, summary string) error {
+	dir, err := cacheDir()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, blobKey), []byte(summary), 0600)
+}
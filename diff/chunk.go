@@ -0,0 +1,56 @@
+package diff
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// SummarizeFunc turns one file's diff into a short summary. It's supplied
+// by the caller so this package doesn't need to depend on the providers
+// package.
+type SummarizeFunc func(ctx context.Context, prompt string) (string, error)
+
+// Options controls how a large diff gets chunked before being handed to the
+// model.
+type Options struct {
+	MaxTokens      int  // 0 disables chunking
+	PerFileSummary bool // force the map step even if the diff already fits
+}
+
+// Prepare returns fullDiff unchanged when it fits within opts.MaxTokens.
+// Otherwise it runs the map step: summarize each file's changes into 1-3
+// bullet points (skipping noisy files and reusing cached summaries keyed by
+// blob SHA), and returns the combined digest. The reduce step is just the
+// normal commit-message prompt, which synthesizes a Conventional Commit
+// from the bullets the same way it would from a raw diff.
+func Prepare(ctx context.Context, fullDiff string, opts Options, summarize SummarizeFunc) (result string, chunked bool, err error) {
+	needsChunking := opts.PerFileSummary || (opts.MaxTokens > 0 && EstimateTokens(fullDiff) > opts.MaxTokens)
+	if !needsChunking {
+		return fullDiff, false, nil
+	}
+
+	files := SplitByFile(fullDiff)
+	var digest strings.Builder
+
+	for _, f := range files {
+		if IsNoisy(f.Path) {
+			fmt.Fprintf(&digest, "- %s: dependency/generated file changed (diff omitted)\n", f.Path)
+			continue
+		}
+
+		summary, cached := CachedSummary(f.BlobKey)
+		if !cached {
+			prompt := fmt.Sprintf("Summarize this file's changes in 1-3 short bullet points:\n\n%s", f.Text())
+			summary, err = summarize(ctx, prompt)
+			if err != nil {
+				return "", false, err
+			}
+			_ = StoreSummary(f.BlobKey, summary) // caching is an optimization, not correctness-critical
+		}
+
+		fmt.Fprintf(&digest, "File: %s\n%s\n\n", f.Path, strings.TrimSpace(summary))
+	}
+
+	return digest.String(), true, nil
+}
@@ -0,0 +1,125 @@
+package diff
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestSplitByFile(t *testing.T) {
+	fullDiff := `diff --git a/foo.go b/foo.go
+index 1111111..2222222 100644
+--- a/foo.go
++++ b/foo.go
+@@ -1,1 +1,1 @@
+-old
++new
+diff --git a/bar.go b/bar.go
+index 3333333..4444444 100644
+--- a/bar.go
++++ b/bar.go
+@@ -1,1 +1,1 @@
+-old bar
++new bar
+`
+
+	files := SplitByFile(fullDiff)
+	if len(files) != 2 {
+		t.Fatalf("got %d files, want 2", len(files))
+	}
+	if files[0].Path != "foo.go" || files[0].BlobKey != "2222222" {
+		t.Errorf("file 0 = %+v", files[0])
+	}
+	if files[1].Path != "bar.go" || files[1].BlobKey != "4444444" {
+		t.Errorf("file 1 = %+v", files[1])
+	}
+	if !strings.Contains(files[0].Text(), "-old\n+new") {
+		t.Errorf("file 0 Text() = %q, missing body", files[0].Text())
+	}
+}
+
+func TestIsNoisy(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"package-lock.json", true},
+		{"vendor/github.com/foo/bar.go", true},
+		{"node_modules/left-pad/index.js", true},
+		{"main.go", false},
+		{"cmd/server/main.go", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsNoisy(tt.path); got != tt.want {
+			t.Errorf("IsNoisy(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestCacheRoundTrip(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if _, ok := CachedSummary("deadbeef"); ok {
+		t.Fatal("expected cache miss before any Store")
+	}
+
+	if err := StoreSummary("deadbeef", "- did a thing"); err != nil {
+		t.Fatalf("StoreSummary: %v", err)
+	}
+
+	summary, ok := CachedSummary("deadbeef")
+	if !ok || summary != "- did a thing" {
+		t.Fatalf("CachedSummary = %q, %v, want %q, true", summary, ok, "- did a thing")
+	}
+}
+
+func TestPrepareSkipsMapStepWhenSmall(t *testing.T) {
+	small := "diff --git a/foo.go b/foo.go\nindex 1..2 100644\n--- a/foo.go\n+++ b/foo.go\n@@ -1 +1 @@\n-old\n+new\n"
+
+	called := false
+	summarize := func(ctx context.Context, prompt string) (string, error) {
+		called = true
+		return "summary", nil
+	}
+
+	result, chunked, err := Prepare(context.Background(), small, Options{MaxTokens: 1_000_000}, summarize)
+	if err != nil {
+		t.Fatalf("Prepare: %v", err)
+	}
+	if chunked {
+		t.Error("expected chunked=false for a small diff")
+	}
+	if result != small {
+		t.Errorf("Prepare returned %q, want the diff unchanged", result)
+	}
+	if called {
+		t.Error("summarize should not be called when chunking isn't needed")
+	}
+}
+
+func TestPrepareReusesCachedSummary(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	fullDiff := "diff --git a/foo.go b/foo.go\nindex aaaa..bbbb 100644\n--- a/foo.go\n+++ b/foo.go\n@@ -1 +1 @@\n-old\n+new\n"
+
+	calls := 0
+	summarize := func(ctx context.Context, prompt string) (string, error) {
+		calls++
+		return "- changed foo", nil
+	}
+
+	if _, _, err := Prepare(context.Background(), fullDiff, Options{PerFileSummary: true}, summarize); err != nil {
+		t.Fatalf("first Prepare: %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected 1 summarize call on first run, got %d", calls)
+	}
+
+	if _, _, err := Prepare(context.Background(), fullDiff, Options{PerFileSummary: true}, summarize); err != nil {
+		t.Fatalf("second Prepare: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected cached summary to skip the second summarize call, got %d total calls", calls)
+	}
+}
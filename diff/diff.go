@@ -0,0 +1,103 @@
+// Package diff splits a unified git diff into per-file pieces and chunks
+// oversized diffs into per-file summaries so they fit in a model's context
+// budget.
+package diff
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// FileDiff is one file's worth of a unified diff, as produced by
+// `git diff`.
+type FileDiff struct {
+	Path    string
+	BlobKey string // cache key: the post-image blob SHA, or a hash of the diff body if that's unavailable
+	Header  []string
+	Body    []string
+}
+
+// Text renders the file diff back into unified diff form.
+func (f FileDiff) Text() string {
+	var b strings.Builder
+	for _, line := range f.Header {
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	for _, line := range f.Body {
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// SplitByFile splits a unified diff produced by `git diff` into one
+// FileDiff per "diff --git" section.
+func SplitByFile(fullDiff string) []FileDiff {
+	var files []FileDiff
+	var current *FileDiff
+
+	for _, line := range strings.Split(fullDiff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			if current != nil {
+				files = append(files, *current)
+			}
+			current = &FileDiff{Path: filePathFromHeader(line), Header: []string{line}}
+		case current == nil:
+			continue
+		case strings.HasPrefix(line, "index "):
+			current.BlobKey = blobKeyFromIndexLine(line)
+			current.Header = append(current.Header, line)
+		case strings.HasPrefix(line, "---"), strings.HasPrefix(line, "+++"):
+			current.Header = append(current.Header, line)
+		default:
+			current.Body = append(current.Body, line)
+		}
+	}
+	if current != nil {
+		files = append(files, *current)
+	}
+
+	for i := range files {
+		if files[i].BlobKey == "" {
+			files[i].BlobKey = hashText(files[i].Text())
+		}
+	}
+
+	return files
+}
+
+func filePathFromHeader(line string) string {
+	fields := strings.Fields(line)
+	if len(fields) < 4 {
+		return ""
+	}
+	return strings.TrimPrefix(fields[3], "b/")
+}
+
+// blobKeyFromIndexLine pulls the post-image blob SHA out of a diff's
+// "index <old>..<new> <mode>" line. It returns "" when the new blob is the
+// all-zero placeholder git uses for working-tree-only content that was
+// never hashed into the object store.
+func blobKeyFromIndexLine(line string) string {
+	fields := strings.Fields(strings.TrimPrefix(line, "index "))
+	if len(fields) == 0 {
+		return ""
+	}
+	shas := strings.SplitN(fields[0], "..", 2)
+	if len(shas) != 2 {
+		return ""
+	}
+	newSHA := shas[1]
+	if newSHA == "" || strings.Trim(newSHA, "0") == "" {
+		return ""
+	}
+	return newSHA
+}
+
+func hashText(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])[:16]
+}
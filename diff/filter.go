@@ -0,0 +1,43 @@
+package diff
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+var lockfileNames = []string{
+	"package-lock.json", "yarn.lock", "pnpm-lock.yaml",
+	"Cargo.lock", "go.sum", "Gemfile.lock", "poetry.lock", "composer.lock",
+}
+
+var vendoredDirs = []string{"vendor/", "node_modules/", "third_party/"}
+
+// IsNoisy reports whether path is a lockfile, vendored dependency, or marked
+// linguist-generated -- the kind of change that should collapse into a
+// one-line note instead of being spent on the model's context budget.
+func IsNoisy(path string) bool {
+	base := filepath.Base(path)
+	for _, name := range lockfileNames {
+		if base == name {
+			return true
+		}
+	}
+	for _, dir := range vendoredDirs {
+		if strings.Contains(path, dir) {
+			return true
+		}
+	}
+	return isLinguistGenerated(path)
+}
+
+// isLinguistGenerated shells out to `git check-attr` so it honors whatever
+// linguist-generated rules the repo's own .gitattributes already define,
+// rather than reimplementing gitattributes pattern matching.
+func isLinguistGenerated(path string) bool {
+	out, err := exec.Command("git", "check-attr", "linguist-generated", "--", path).Output()
+	if err != nil {
+		return false
+	}
+	return strings.Contains(string(out), "linguist-generated: true")
+}
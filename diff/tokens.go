@@ -0,0 +1,10 @@
+package diff
+
+// EstimateTokens gives a fast, tokenizer-free approximation of how many
+// model tokens a piece of text costs. ~4 bytes per token is a common
+// tiktoken-compatible rule of thumb for GPT/Claude-style BPE vocabularies on
+// typical source diffs, and avoids pulling in a real tokenizer dependency
+// just to decide whether to chunk.
+func EstimateTokens(s string) int {
+	return (len(s) + 3) / 4
+}
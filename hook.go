@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// prepareCommitMsgHook is installed as .git/hooks/prepare-commit-msg. It
+// only fills in a message for a plain `git commit` with no message source
+// of its own ($2 empty) -- merges, amends, squashes, and anything already
+// carrying a template or -m are left untouched.
+const prepareCommitMsgHook = `#!/bin/sh
+# Installed by easy-commits install-hook.
+if [ -n "$2" ]; then
+  exit 0
+fi
+
+message=$(easy-commits commit --no-interactive --print 2>/dev/null)
+if [ -n "$message" ]; then
+  echo "$message" > "$1"
+fi
+`
+
+func handleInstallHook() {
+	if !isGitRepo() {
+		fmt.Println("Error: Not in a git repository")
+		return
+	}
+
+	gitDir, err := gitDir()
+	if err != nil {
+		fmt.Printf("Error locating .git directory: %v\n", err)
+		return
+	}
+
+	hookPath := filepath.Join(gitDir, "hooks", "prepare-commit-msg")
+	if err := os.WriteFile(hookPath, []byte(prepareCommitMsgHook), 0755); err != nil {
+		fmt.Printf("Error installing hook: %v\n", err)
+		return
+	}
+
+	fmt.Printf("Installed prepare-commit-msg hook at %s\n", hookPath)
+	fmt.Println("Every `git commit` without an explicit -m will now get an AI-generated message, including from your editor/IDE.")
+}
+
+func gitDir() (string, error) {
+	out, err := exec.Command("git", "rev-parse", "--git-dir").Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
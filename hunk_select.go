@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// hunkSelectModel is a `git add -p` style checklist: space toggles a hunk,
+// enter confirms the current selection as one proposed commit.
+type hunkSelectModel struct {
+	hunks   []*Hunk
+	cursor  int
+	confirm bool
+	cancel  bool
+}
+
+func newHunkSelectModel(hunks []*Hunk) hunkSelectModel {
+	return hunkSelectModel{hunks: hunks}
+}
+
+func (m hunkSelectModel) Init() tea.Cmd { return nil }
+
+func (m hunkSelectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch keyMsg.String() {
+	case "q", "ctrl+c":
+		m.cancel = true
+		return m, tea.Quit
+	case "j", "down":
+		if m.cursor < len(m.hunks)-1 {
+			m.cursor++
+		}
+	case "k", "up":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case " ":
+		m.hunks[m.cursor].Selected = !m.hunks[m.cursor].Selected
+	case "enter":
+		m.confirm = true
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+func (m hunkSelectModel) View() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Select hunks for this commit"))
+	b.WriteString("\n\n")
+
+	for i, h := range m.hunks {
+		box := "[ ]"
+		if h.Selected {
+			box = "[x]"
+		}
+		cursor := "  "
+		if i == m.cursor {
+			cursor = "> "
+		}
+		summary := fmt.Sprintf("%s%s %s (%s)", cursor, box, h.FilePath, firstLine(h.Body))
+		if i == m.cursor {
+			summary = titleStyle.Render(summary)
+		}
+		b.WriteString(summary)
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("j/k move · space toggle · enter commit selected · q cancel"))
+	return b.String()
+}
+
+func firstLine(lines []string) string {
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.TrimSpace(lines[0])
+}
+
+// selectHunks drives the hunk checklist TUI and returns the hunks the user
+// checked, or nil if they cancelled.
+func selectHunks(hunks []*Hunk) ([]*Hunk, error) {
+	p := tea.NewProgram(newHunkSelectModel(hunks))
+	final, err := p.Run()
+	if err != nil {
+		return nil, err
+	}
+
+	m := final.(hunkSelectModel)
+	if m.cancel {
+		return nil, nil
+	}
+
+	var selected []*Hunk
+	for _, h := range m.hunks {
+		if h.Selected {
+			selected = append(selected, h)
+		}
+	}
+	return selected, nil
+}
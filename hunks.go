@@ -0,0 +1,87 @@
+package main
+
+import "strings"
+
+// Hunk is a single @@ ... @@ block from a unified diff, together with the
+// file-level header lines (diff --git, index, ---, +++) it belongs to. It's
+// the unit the split-commit workflow lets the user pick and choose from.
+type Hunk struct {
+	FilePath string
+	Header   []string
+	Body     []string
+	Selected bool
+}
+
+// Patch renders the hunk back into a standalone unified diff that
+// `git apply --cached` can consume on its own.
+func (h *Hunk) Patch() string {
+	var b strings.Builder
+	for _, line := range h.Header {
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	for _, line := range h.Body {
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// parseHunks splits a unified diff produced by `git diff` into individual
+// hunks. It's a best-effort parser aimed at the split-commit review flow,
+// not a general-purpose patch parser: a file section with no @@ line (a
+// pure rename, a mode-only change, a binary file) yields no hunk at all,
+// and combined diffs ("diff --cc") are not recognised as file headers.
+func parseHunks(diff string) []*Hunk {
+	var hunks []*Hunk
+	var header []string
+	var filePath string
+	var current *Hunk
+
+	for _, line := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			header = []string{line}
+			filePath = filePathFromDiffLine(line)
+			current = nil
+		case strings.HasPrefix(line, "@@"):
+			current = &Hunk{FilePath: filePath, Header: append([]string(nil), header...), Selected: true}
+			current.Body = append(current.Body, line)
+			hunks = append(hunks, current)
+		case current != nil:
+			current.Body = append(current.Body, line)
+		default:
+			header = append(header, line)
+		}
+	}
+
+	return hunks
+}
+
+// filePathFromDiffLine extracts "b/foo/bar.go" -> "foo/bar.go" from a
+// "diff --git a/foo/bar.go b/foo/bar.go" header line.
+func filePathFromDiffLine(line string) string {
+	fields := strings.Fields(line)
+	if len(fields) < 4 {
+		return ""
+	}
+	return strings.TrimPrefix(fields[3], "b/")
+}
+
+// buildPatch concatenates the selected hunks, grouped by file, into a single
+// patch suitable for `git apply --cached`.
+func buildPatch(hunks []*Hunk) string {
+	var b strings.Builder
+	lastHeader := ""
+	for _, h := range hunks {
+		header := strings.Join(h.Header, "\n")
+		if header != lastHeader {
+			b.WriteString(header)
+			b.WriteString("\n")
+			lastHeader = header
+		}
+		b.WriteString(strings.Join(h.Body, "\n"))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,40 @@
+package index
+
+import (
+	"hash/fnv"
+	"math"
+	"strings"
+)
+
+// fallbackDims is the size of the feature-hashed embedding used when no
+// embedding-capable provider is configured.
+const fallbackDims = 256
+
+// FallbackEmbed produces a crude bag-of-words embedding via feature
+// hashing, so retrieval still works without a configured embedding
+// endpoint. It's far weaker than a real embedding model but is enough to
+// group commits that touch similar files and words.
+func FallbackEmbed(text string) []float32 {
+	vec := make([]float32, fallbackDims)
+	for _, word := range strings.Fields(strings.ToLower(text)) {
+		h := fnv.New32a()
+		h.Write([]byte(word))
+		vec[h.Sum32()%fallbackDims]++
+	}
+	normalize(vec)
+	return vec
+}
+
+func normalize(vec []float32) {
+	var sumSq float64
+	for _, v := range vec {
+		sumSq += float64(v) * float64(v)
+	}
+	if sumSq == 0 {
+		return
+	}
+	norm := float32(math.Sqrt(sumSq))
+	for i := range vec {
+		vec[i] /= norm
+	}
+}
@@ -0,0 +1,141 @@
+// Package index implements a small on-disk retrieval index over a repo's
+// commit history: no external vector DB, just a JSON file of embeddings
+// searched with cosine similarity.
+package index
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Example is one historical commit's (diff-summary, message) pair together
+// with the embedding of its summary, used as a few-shot example at
+// generation time.
+type Example struct {
+	Summary   string    `json:"summary"`
+	Message   string    `json:"message"`
+	Embedding []float32 `json:"embedding"`
+}
+
+// EmbedFunc embeds a single piece of text, backed by either a provider's
+// embedding endpoint or the pure-Go fallback.
+type EmbedFunc func(ctx context.Context, text string) ([]float32, error)
+
+// Dir returns ~/.easy-commits-index/<repo-hash>/, creating it if needed, so
+// each repository gets its own index.
+func Dir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	root, err := repoRoot()
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256([]byte(root))
+
+	dir := filepath.Join(home, ".easy-commits-index", hex.EncodeToString(hash[:])[:16])
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+func repoRoot() (string, error) {
+	out, err := exec.Command("git", "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func examplesPath(dir string) string {
+	return filepath.Join(dir, "examples.json")
+}
+
+// Load reads the on-disk example set, returning nil if `index` hasn't been
+// run yet.
+func Load() ([]Example, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(examplesPath(dir))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var examples []Example
+	if err := json.Unmarshal(data, &examples); err != nil {
+		return nil, err
+	}
+	return examples, nil
+}
+
+// Save overwrites the on-disk example set.
+func Save(examples []Example) error {
+	dir, err := Dir()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(examples, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(examplesPath(dir), data, 0600)
+}
+
+// TopK returns the k examples whose embedding is most cosine-similar to
+// query, most similar first.
+func TopK(examples []Example, query []float32, k int) []Example {
+	type scored struct {
+		Example
+		score float64
+	}
+
+	scoredExamples := make([]scored, len(examples))
+	for i, e := range examples {
+		scoredExamples[i] = scored{e, cosineSimilarity(e.Embedding, query)}
+	}
+	sort.Slice(scoredExamples, func(i, j int) bool { return scoredExamples[i].score > scoredExamples[j].score })
+
+	if k > len(scoredExamples) {
+		k = len(scoredExamples)
+	}
+	top := make([]Example, k)
+	for i := 0; i < k; i++ {
+		top[i] = scoredExamples[i].Example
+	}
+	return top
+}
+
+func cosineSimilarity(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
@@ -0,0 +1,58 @@
+package index
+
+import "testing"
+
+func TestTopKOrdersByCosineSimilarity(t *testing.T) {
+	examples := []Example{
+		{Summary: "unrelated", Message: "chore: bump deps", Embedding: []float32{1, 0}},
+		{Summary: "exact match", Message: "feat: add login", Embedding: []float32{0, 1}},
+		{Summary: "somewhat similar", Message: "fix: login bug", Embedding: []float32{0.2, 0.8}},
+	}
+	query := []float32{0, 1}
+
+	top := TopK(examples, query, 2)
+	if len(top) != 2 {
+		t.Fatalf("got %d results, want 2", len(top))
+	}
+	if top[0].Message != "feat: add login" {
+		t.Errorf("top[0] = %q, want the exact-match example first", top[0].Message)
+	}
+	if top[1].Message != "fix: login bug" {
+		t.Errorf("top[1] = %q, want the second-most-similar example", top[1].Message)
+	}
+}
+
+func TestTopKClampsToAvailableExamples(t *testing.T) {
+	examples := []Example{{Embedding: []float32{1, 0}}}
+	top := TopK(examples, []float32{1, 0}, 5)
+	if len(top) != 1 {
+		t.Fatalf("got %d results, want 1 (only example available)", len(top))
+	}
+}
+
+func TestCosineSimilarityDimensionMismatch(t *testing.T) {
+	if got := cosineSimilarity([]float32{1, 0}, []float32{1, 0, 0}); got != 0 {
+		t.Errorf("cosineSimilarity with mismatched dims = %v, want 0", got)
+	}
+	if got := cosineSimilarity(nil, nil); got != 0 {
+		t.Errorf("cosineSimilarity(nil, nil) = %v, want 0", got)
+	}
+}
+
+func TestFallbackEmbedIsDeterministicAndNormalized(t *testing.T) {
+	a := FallbackEmbed("main.go changed")
+	b := FallbackEmbed("main.go changed")
+
+	if len(a) != len(b) {
+		t.Fatalf("FallbackEmbed lengths differ: %d vs %d", len(a), len(b))
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("FallbackEmbed not deterministic at index %d: %v vs %v", i, a[i], b[i])
+		}
+	}
+
+	if sim := cosineSimilarity(a, b); sim < 0.999 {
+		t.Errorf("identical text should embed to (near-)identical vectors, cosine similarity = %v", sim)
+	}
+}
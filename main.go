@@ -2,40 +2,32 @@ package main
 
 import (
 	"bufio"
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
 	"os"
 	"os/exec"
+	"os/signal"
+	"strconv"
 	"strings"
-	"time"
-)
-
-type Config struct {
-	Provider string `json:"provider"`
-	APIKey   string `json:"api_key"`
-	Model    string `json:"model"`
-	BaseURL  string `json:"base_url,omitempty"`
-}
-
-type OpenAIRequest struct {
-	Model    string    `json:"model"`
-	Messages []Message `json:"messages"`
-}
 
-type Message struct {
-	Role    string `json:"role"`
-	Content string `json:"content"`
-}
+	diffchunk "github.com/josiah-hester/easy-commits/diff"
+	ragindex "github.com/josiah-hester/easy-commits/index"
+	"github.com/josiah-hester/easy-commits/providers"
+)
 
-type OpenAIResponse struct {
-	Choices []Choice `json:"choices"`
-}
+// defaultMaxDiffTokens caps the diff at a size that leaves plenty of room in
+// even a small model's context window before per-file summarization kicks
+// in; --max-tokens overrides it.
+const defaultMaxDiffTokens = 6000
 
-type Choice struct {
-	Message Message `json:"message"`
+type Config struct {
+	Provider       string        `json:"provider"`
+	APIKey         string        `json:"api_key"`
+	Model          string        `json:"model"`
+	BaseURL        string        `json:"base_url,omitempty"`
+	StyleProfile   *StyleProfile `json:"style_profile,omitempty"`
+	EmbeddingModel string        `json:"embedding_model,omitempty"`
 }
 
 func main() {
@@ -49,6 +41,12 @@ func main() {
 		handleConfig()
 	case "commit":
 		handleCommit()
+	case "learn":
+		handleLearn()
+	case "install-hook":
+		handleInstallHook()
+	case "index":
+		handleIndex()
 	case "help":
 		printUsage()
 	default:
@@ -63,8 +61,22 @@ func printUsage() {
 	fmt.Println("Usage:")
 	fmt.Println("  easy-commits config    Configure AI provider and API key")
 	fmt.Println("  easy-commits commit    Generate and create a commit with AI-generated message")
+	fmt.Println("  easy-commits learn     Learn this repo's commit style from its git history")
+	fmt.Println("  easy-commits install-hook  Install a prepare-commit-msg hook that fills in AI messages automatically")
+	fmt.Println("  easy-commits index     Embed this repo's commit history for style retrieval")
 	fmt.Println("  easy-commits help      Show this help message")
 	fmt.Println()
+	fmt.Println("Commit flags:")
+	fmt.Println("  --context \"...\"       Extra context to steer the generated message")
+	fmt.Println("  --no-interactive      Skip the review TUI and fall back to a plain y/n prompt")
+	fmt.Println("  --all                 Stage all changes first (easy-commits no longer does this by default)")
+	fmt.Println("  --print               Print the generated message only, used by the installed hook")
+	fmt.Println("  --max-tokens N        Diff token budget before per-file summarization kicks in (default 6000)")
+	fmt.Println("  --per-file-summary    Always summarize file-by-file instead of sending the raw diff")
+	fmt.Println()
+	fmt.Println("Index flags:")
+	fmt.Println("  --count N             Number of recent commits to embed (default 500)")
+	fmt.Println()
 	fmt.Println("Examples:")
 	fmt.Println("  easy-commits config")
 	fmt.Println("  easy-commits commit")
@@ -74,7 +86,7 @@ func printUsage() {
 func handleConfig() {
 	config := Config{}
 
-	fmt.Print("Select AI provider (openai/anthropic/ollama): ")
+	fmt.Printf("Select AI provider (%s): ", strings.Join(providers.Names(), "/"))
 	fmt.Scanln(&config.Provider)
 
 	if config.Provider == "ollama" {
@@ -100,44 +112,49 @@ func handleConfig() {
 		}
 	}
 
-	configData, err := json.MarshalIndent(config, "", "  ")
-	if err != nil {
-		fmt.Printf("Error marshaling config: %v\n", err)
-		return
-	}
-
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		fmt.Printf("Error getting home directory: %v\n", err)
-		return
+	if _, ok := providers.Get(config.Provider); ok {
+		fmt.Print("Enter embedding model for style retrieval (optional, e.g. text-embedding-3-small): ")
+		fmt.Scanln(&config.EmbeddingModel)
 	}
 
-	configPath := fmt.Sprintf("%s/.easy-commits-config.json", homeDir)
-	err = os.WriteFile(configPath, configData, 0600)
-	if err != nil {
-		fmt.Printf("Error writing config file: %v\n", err)
+	if err := saveConfig(&config); err != nil {
+		fmt.Printf("Error saving config: %v\n", err)
 		return
 	}
 
-	fmt.Printf("Configuration saved to %s\n", configPath)
+	fmt.Println("Configuration saved")
 }
 
 func handleCommit() {
+	// Cancel any in-flight provider request on Ctrl-C.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
 	// Check if we're in a git repository
 	if !isGitRepo() {
 		fmt.Println("Error: Not in a git repository")
 		return
 	}
 
-	// Get git diff
-	diff, err := getGitDiff()
+	printOnly := hasFlag("--print")
+
+	if hasFlag("--all") {
+		if err := stageAllChanges(); err != nil {
+			fmt.Printf("Error staging changes: %v\n", err)
+			return
+		}
+	}
+
+	// Only ever commit what's already staged -- easy-commits used to run
+	// `git add .` here, which silently swept up unrelated changes.
+	diff, err := getStagedDiff()
 	if err != nil {
 		fmt.Printf("Error getting git diff: %v\n", err)
 		return
 	}
 
 	if strings.TrimSpace(diff) == "" {
-		fmt.Println("No changes to commit")
+		fmt.Println("No staged changes to commit. Stage changes with `git add` or pass --all.")
 		return
 	}
 
@@ -158,33 +175,128 @@ func handleCommit() {
 		return
 	}
 
-	// Generate commit message
-	commitMessage, err := generateCommitMessage(config, diff, userContext)
+	interactive := !hasFlag("--no-interactive") && !printOnly
+	maxTokens := defaultMaxDiffTokens
+	perFileSummary := hasFlag("--per-file-summary")
+	for i, arg := range os.Args {
+		if arg == "--max-tokens" && i+1 < len(os.Args) {
+			if n, err := strconv.Atoi(os.Args[i+1]); err == nil {
+				maxTokens = n
+			}
+		}
+	}
+
+	promptDiff, chunked, err := prepareDiffForPrompt(ctx, config, diff, diffchunk.Options{MaxTokens: maxTokens, PerFileSummary: perFileSummary})
+	if err != nil {
+		fmt.Printf("Error summarizing diff: %v\n", err)
+		return
+	}
+	if chunked && !printOnly {
+		fmt.Println("Diff is large; summarized it file-by-file before generating the commit message")
+	}
+
+	// Generate commit message. In interactive mode, draft tokens stream to
+	// stdout as they arrive for progress feedback -- the review TUI takes
+	// over the screen right after, so the raw draft is harmless there. In
+	// --print mode and the plain y/n fallback, streaming is suppressed and
+	// the final (validated, trailer-appended) message is printed exactly
+	// once, so stdout always matches what actually gets committed.
+	if !printOnly {
+		fmt.Println("Generated commit message:")
+		fmt.Println("=" + strings.Repeat("=", 50))
+	}
+	commitMessage, err := generateCommitMessage(ctx, config, promptDiff, userContext, interactive)
 	if err != nil {
 		fmt.Printf("Error generating commit message: %v\n", err)
 		return
 	}
+	if !printOnly {
+		if !interactive {
+			fmt.Println(commitMessage)
+		}
+		fmt.Println("=" + strings.Repeat("=", 50))
+	}
 
-	// Show the generated message and ask for confirmation
-	fmt.Println("Generated commit message:")
-	fmt.Println("=" + strings.Repeat("=", 50))
-	fmt.Println(commitMessage)
-	fmt.Println("=" + strings.Repeat("=", 50))
-	fmt.Print("Use this commit message? (y/n): ")
+	if printOnly {
+		fmt.Print(commitMessage)
+		return
+	}
+
+	if !interactive {
+		fmt.Print("Use this commit message? (y/n): ")
+
+		reader := bufio.NewReader(os.Stdin)
+		response, _ := reader.ReadString('\n')
+		response = strings.TrimSpace(strings.ToLower(response))
+
+		if response == "y" || response == "yes" {
+			if err := createCommit(commitMessage); err != nil {
+				fmt.Printf("Error creating commit: %v\n", err)
+				return
+			}
+			fmt.Println("Commit created successfully!")
+		} else {
+			fmt.Println("Commit cancelled")
+		}
+		return
+	}
 
-	reader := bufio.NewReader(os.Stdin)
-	response, _ := reader.ReadString('\n')
-	response = strings.TrimSpace(strings.ToLower(response))
+	if err := reviewLoop(ctx, config, diff, promptDiff, userContext, commitMessage); err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+}
 
-	if response == "y" || response == "yes" {
-		err = createCommit(commitMessage)
+// reviewLoop drives the interactive TUI: accept, edit, regenerate with extra
+// context, or hand off to the split-commit workflow. It replaces the plain
+// y/n prompt for anyone not passing --no-interactive. stagedDiff is the raw
+// staged diff the split workflow parses into hunks; diff is what the model
+// and the review pane see, which may be the per-file summary digest.
+func reviewLoop(ctx context.Context, config *Config, stagedDiff, diff, userContext, message string) error {
+	for {
+		result, err := runReview(diff, message)
 		if err != nil {
-			fmt.Printf("Error creating commit: %v\n", err)
-			return
+			return err
+		}
+
+		switch result.action {
+		case actionAccept:
+			if err := createCommit(result.message); err != nil {
+				return fmt.Errorf("failed to create commit: %v", err)
+			}
+			fmt.Println("Commit created successfully!")
+			return nil
+
+		case actionEdit:
+			edited, err := editInEditor(result.message)
+			if err != nil {
+				return err
+			}
+			if err := createCommit(edited); err != nil {
+				return fmt.Errorf("failed to create commit: %v", err)
+			}
+			fmt.Println("Commit created successfully!")
+			return nil
+
+		case actionRegenerate:
+			fmt.Print("Additional context for regeneration: ")
+			reader := bufio.NewReader(os.Stdin)
+			extra, _ := reader.ReadString('\n')
+			extra = strings.TrimSpace(extra)
+			if extra != "" {
+				userContext = strings.TrimSpace(userContext + " " + extra)
+			}
+			message, err = generateCommitMessage(ctx, config, diff, userContext, true)
+			if err != nil {
+				return err
+			}
+
+		case actionSplit:
+			return runSplitCommit(ctx, config, stagedDiff, userContext)
+
+		default:
+			fmt.Println("Commit cancelled")
+			return nil
 		}
-		fmt.Println("Commit created successfully!")
-	} else {
-		fmt.Println("Commit cancelled")
 	}
 }
 
@@ -193,23 +305,30 @@ func isGitRepo() bool {
 	return cmd.Run() == nil
 }
 
-func getGitDiff() (string, error) {
-	cmd := exec.Command("git", "diff", "--cached")
-	output, err := cmd.Output()
+// getStagedDiff returns the diff of what's already staged. easy-commits
+// only ever commits staged changes; use --all to stage everything first.
+func getStagedDiff() (string, error) {
+	output, err := exec.Command("git", "diff", "--cached").Output()
 	if err != nil {
 		return "", err
 	}
+	return string(output), nil
+}
 
-	// If no staged changes, get unstaged changes
-	if strings.TrimSpace(string(output)) == "" {
-		cmd = exec.Command("git", "diff")
-		output, err = cmd.Output()
-		if err != nil {
-			return "", err
+// stageAllChanges implements the --all opt-in: stage everything, tracked
+// and untracked, the way `git add .` used to unconditionally.
+func stageAllChanges() error {
+	return exec.Command("git", "add", "-A").Run()
+}
+
+// hasFlag reports whether flag appears anywhere in the process arguments.
+func hasFlag(flag string) bool {
+	for _, arg := range os.Args {
+		if arg == flag {
+			return true
 		}
 	}
-
-	return string(output), nil
+	return false
 }
 
 func loadConfig() (*Config, error) {
@@ -229,200 +348,170 @@ func loadConfig() (*Config, error) {
 	return &config, err
 }
 
-func generateCommitMessage(config *Config, diff, userContext string) (string, error) {
-	prompt := buildPrompt(diff, userContext)
-
-	switch config.Provider {
-	case "openai":
-		return callOpenAI(config, prompt)
-	case "anthropic":
-		return callAnthropic(config, prompt)
-	case "ollama":
-		return callOllama(config, prompt)
-	default:
-		return "", fmt.Errorf("unsupported provider: %s", config.Provider)
+func saveConfig(config *Config) error {
+	configData, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return err
 	}
-}
-
-func buildPrompt(diff, userContext string) string {
-	prompt := `You are an expert at writing clear, concise git commit messages. Based on the git diff provided, generate a commit message that follows these guidelines:
-
-1. Use the conventional commit format: type(scope): description
-2. Types: feat, fix, docs, style, refactor, test, chore
-3. Keep the first line under 50 characters
-4. Use imperative mood ("add" not "added")
-5. Be specific about what changed and why
-
-Git diff:
-` + diff
 
-	if userContext != "" {
-		prompt += "\n\nAdditional context from user: " + userContext
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return err
 	}
 
-	prompt += "\n\nGenerate only the commit message, no additional text or explanation."
-
-	return prompt
+	configPath := fmt.Sprintf("%s/.easy-commits-config.json", homeDir)
+	return os.WriteFile(configPath, configData, 0600)
 }
 
-func callOpenAI(config *Config, prompt string) (string, error) {
-	reqBody := OpenAIRequest{
-		Model: config.Model,
-		Messages: []Message{
-			{Role: "user", Content: prompt},
-		},
+// prepareDiffForPrompt runs the diff through the map-reduce chunking
+// pipeline when it's too large for the configured provider to see in full,
+// using that same provider to summarize each file.
+func prepareDiffForPrompt(ctx context.Context, config *Config, diff string, opts diffchunk.Options) (string, bool, error) {
+	provider, ok := providers.Get(config.Provider)
+	if !ok {
+		return "", false, fmt.Errorf("unsupported provider: %s", config.Provider)
 	}
 
-	jsonData, err := json.Marshal(reqBody)
-	if err != nil {
-		return "", err
+	providerOpts := providers.Options{
+		APIKey:  config.APIKey,
+		Model:   config.Model,
+		BaseURL: config.BaseURL,
 	}
 
-	req, err := http.NewRequest("POST", "https://api.openai.com/v1/chat/completions", bytes.NewBuffer(jsonData))
-	if err != nil {
-		return "", err
+	summarize := func(ctx context.Context, prompt string) (string, error) {
+		return provider.Generate(ctx, prompt, providerOpts)
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+config.APIKey)
+	return diffchunk.Prepare(ctx, diff, opts, summarize)
+}
 
-	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Do(req)
-	if err != nil {
-		return "", err
+// generateCommitMessage drafts a commit message, validates/repairs it, and
+// appends a ticket trailer if one can be inferred. When streamToStdout is
+// true, draft tokens are echoed to stdout as they arrive for progress
+// feedback; callers whose stdout must equal the final message exactly (the
+// --print hook path, the plain y/n prompt) pass false and print the
+// returned string themselves once it's final.
+func generateCommitMessage(ctx context.Context, config *Config, diff, userContext string, streamToStdout bool) (string, error) {
+	examples := retrieveExamples(ctx, config, diff)
+	prompt := buildPrompt(diff, userContext, config.StyleProfile, examples)
+
+	provider, ok := providers.Get(config.Provider)
+	if !ok {
+		return "", fmt.Errorf("unsupported provider: %s", config.Provider)
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", err
+	opts := providers.Options{
+		APIKey:  config.APIKey,
+		Model:   config.Model,
+		BaseURL: config.BaseURL,
 	}
 
-	var openAIResp OpenAIResponse
-	err = json.Unmarshal(body, &openAIResp)
+	chunks, err := provider.GenerateStream(ctx, prompt, opts)
 	if err != nil {
 		return "", err
 	}
 
-	if len(openAIResp.Choices) == 0 {
-		return "", fmt.Errorf("no response from OpenAI")
+	var message strings.Builder
+	for chunk := range chunks {
+		if streamToStdout {
+			fmt.Print(chunk)
+		}
+		message.WriteString(chunk)
 	}
 
-	return strings.TrimSpace(openAIResp.Choices[0].Message.Content), nil
-}
-
-func callAnthropic(config *Config, prompt string) (string, error) {
-	reqBody := map[string]interface{}{
-		"model":      config.Model,
-		"max_tokens": 150,
-		"messages": []map[string]string{
-			{"role": "user", "content": prompt},
-		},
+	if ctx.Err() != nil {
+		return "", ctx.Err()
 	}
 
-	jsonData, err := json.Marshal(reqBody)
+	final, err := ensureConventionalCommit(ctx, provider, opts, prompt, strings.TrimSpace(message.String()))
 	if err != nil {
 		return "", err
 	}
 
-	req, err := http.NewRequest("POST", "https://api.anthropic.com/v1/messages", bytes.NewBuffer(jsonData))
-	if err != nil {
-		return "", err
+	if ticket, ok := inferTicketFromBranch(); ok {
+		final = withTicketTrailer(final, ticket)
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("x-api-key", config.APIKey)
-	req.Header.Set("anthropic-version", "2023-06-01")
-
-	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Do(req)
-	if err != nil {
-		return "", err
-	}
-	defer resp.Body.Close()
+	return final, nil
+}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", err
-	}
+// ensureConventionalCommit validates the generated message against the
+// Conventional Commits spec and repairs it if needed: deterministic fixes
+// (missing prefix, aliased type) are applied locally, anything else is
+// repaired by re-prompting the model with the validation errors attached.
+// If repair still doesn't produce a clean message after a couple of
+// attempts, the most recent attempt is returned (unvalidated) rather than
+// failing the commit outright.
+func ensureConventionalCommit(ctx context.Context, provider providers.Provider, opts providers.Options, prompt, message string) (string, error) {
+	const maxAttempts = 2
+
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		problems := validateConventionalCommit(message)
+		if len(problems) == 0 {
+			return message, nil
+		}
 
-	var anthropicResp map[string]interface{}
-	err = json.Unmarshal(body, &anthropicResp)
-	if err != nil {
-		return "", err
-	}
+		if fixed, ok := autocorrectConventionalCommit(message); ok {
+			message = fixed
+			continue
+		}
 
-	content, ok := anthropicResp["content"].([]interface{})
-	if !ok || len(content) == 0 {
-		return "", fmt.Errorf("no response from Anthropic")
-	}
+		repairPrompt := fmt.Sprintf("%s\n\nYour previous attempt was invalid: %s\n\nPrevious attempt:\n%s\n\nRegenerate a corrected commit message that fixes this.",
+			prompt, strings.Join(problems, "; "), message)
 
-	textContent, ok := content[0].(map[string]interface{})
-	if !ok {
-		return "", fmt.Errorf("invalid response format from Anthropic")
-	}
-
-	text, ok := textContent["text"].(string)
-	if !ok {
-		return "", fmt.Errorf("no text in Anthropic response")
+		fixed, err := provider.Generate(ctx, repairPrompt, opts)
+		if err != nil {
+			return message, nil
+		}
+		message = strings.TrimSpace(fixed)
 	}
 
-	return strings.TrimSpace(text), nil
+	return message, nil
 }
 
-func callOllama(config *Config, prompt string) (string, error) {
-	reqBody := map[string]interface{}{
-		"model":  config.Model,
-		"prompt": prompt,
-		"stream": false,
-	}
-
-	jsonData, err := json.Marshal(reqBody)
-	if err != nil {
-		return "", err
-	}
+func buildPrompt(diff, userContext string, profile *StyleProfile, examples []ragindex.Example) string {
+	prompt := `You are an expert at writing clear, concise git commit messages. Based on the git diff provided, generate a commit message that follows these guidelines:
 
-	url := config.BaseURL + "/api/generate"
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return "", err
-	}
+1. Use the conventional commit format: type(scope): description
+2. Types: feat, fix, docs, style, refactor, test, chore
+3. Keep the first line under 50 characters
+4. Use imperative mood ("add" not "added")
+5. Be specific about what changed and why
 
-	req.Header.Set("Content-Type", "application/json")
+Git diff:
+` + diff
 
-	client := &http.Client{Timeout: 60 * time.Second}
-	resp, err := client.Do(req)
-	if err != nil {
-		return "", err
+	if len(examples) > 0 {
+		prompt += "\n\nHere are similar past commits from this repository's history -- match their style:\n"
+		for _, example := range examples {
+			prompt += fmt.Sprintf("\nChanged files:\n%s\nCommit message:\n%s\n", example.Summary, example.Message)
+		}
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", err
+	if profile != nil {
+		prompt += "\n\nMatch this repository's existing commit style:\n"
+		prompt += fmt.Sprintf("- Preferred types, most to least common: %s\n", strings.Join(profile.Types, ", "))
+		if scopes := topScopes(profile.ScopeCounts, 5); len(scopes) > 0 {
+			prompt += fmt.Sprintf("- Common scopes: %s\n", strings.Join(scopes, ", "))
+		}
+		prompt += fmt.Sprintf("- Aim for a subject line around %d characters\n", profile.AvgSubjectLen)
+		if profile.UsesGitmoji {
+			prompt += "- This repo prefixes subjects with a gitmoji\n"
+		}
 	}
 
-	var ollamaResp map[string]interface{}
-	err = json.Unmarshal(body, &ollamaResp)
-	if err != nil {
-		return "", err
+	if userContext != "" {
+		prompt += "\n\nAdditional context from user: " + userContext
 	}
 
-	response, ok := ollamaResp["response"].(string)
-	if !ok {
-		return "", fmt.Errorf("no response from Ollama")
-	}
+	prompt += "\n\nGenerate only the commit message, no additional text or explanation."
 
-	return strings.TrimSpace(response), nil
+	return prompt
 }
 
+// createCommit commits whatever is already staged. It never stages
+// anything itself -- that's handleCommit's job via --all -- so it doesn't
+// silently sweep unrelated changes into the commit.
 func createCommit(message string) error {
-	cmd := exec.Command("git", "add", ".")
-	err := cmd.Run()
-	if err != nil {
-		return fmt.Errorf("failed to stage changes: %v", err)
-	}
-
-	cmd = exec.Command("git", "commit", "-m", message)
-	return cmd.Run()
+	return exec.Command("git", "commit", "-m", message).Run()
 }
@@ -0,0 +1,158 @@
+package providers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+func init() {
+	Register(&anthropicProvider{})
+}
+
+type anthropicProvider struct{}
+
+func (p *anthropicProvider) Name() string { return "anthropic" }
+
+type anthropicRequest struct {
+	Model     string        `json:"model"`
+	MaxTokens int           `json:"max_tokens"`
+	Messages  []chatMessage `json:"messages"`
+	Stream    bool          `json:"stream"`
+}
+
+func (p *anthropicProvider) Generate(ctx context.Context, prompt string, opts Options) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	reqBody := anthropicRequest{
+		Model:     opts.Model,
+		MaxTokens: 150,
+		Messages:  []chatMessage{{Role: "user", Content: prompt}},
+	}
+
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.anthropic.com/v1/messages", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-api-key", opts.APIKey)
+	req.Header.Set("anthropic-version", "2023-06-01")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	var anthropicResp map[string]interface{}
+	if err := json.Unmarshal(body, &anthropicResp); err != nil {
+		return "", err
+	}
+
+	content, ok := anthropicResp["content"].([]interface{})
+	if !ok || len(content) == 0 {
+		return "", fmt.Errorf("no response from Anthropic")
+	}
+
+	textContent, ok := content[0].(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("invalid response format from Anthropic")
+	}
+
+	text, ok := textContent["text"].(string)
+	if !ok {
+		return "", fmt.Errorf("no text in Anthropic response")
+	}
+
+	return strings.TrimSpace(text), nil
+}
+
+func (p *anthropicProvider) GenerateStream(ctx context.Context, prompt string, opts Options) (<-chan string, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+
+	reqBody := anthropicRequest{
+		Model:     opts.Model,
+		MaxTokens: 150,
+		Messages:  []chatMessage{{Role: "user", Content: prompt}},
+		Stream:    true,
+	}
+
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.anthropic.com/v1/messages", bytes.NewBuffer(jsonData))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-api-key", opts.APIKey)
+	req.Header.Set("anthropic-version", "2023-06-01")
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	out := make(chan string)
+	go func() {
+		defer cancel()
+		defer resp.Body.Close()
+		defer close(out)
+
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			if ctx.Err() != nil {
+				return
+			}
+
+			line := strings.TrimSpace(scanner.Text())
+			data, ok := strings.CutPrefix(line, "data: ")
+			if !ok {
+				continue
+			}
+
+			var event struct {
+				Type  string `json:"type"`
+				Delta struct {
+					Text string `json:"text"`
+				} `json:"delta"`
+			}
+			if err := json.Unmarshal([]byte(data), &event); err != nil {
+				continue
+			}
+			if event.Type == "content_block_delta" && event.Delta.Text != "" {
+				select {
+				case out <- event.Delta.Text:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
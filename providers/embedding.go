@@ -0,0 +1,10 @@
+package providers
+
+import "context"
+
+// EmbeddingProvider is implemented by backends that also expose an
+// embeddings endpoint. Not every Provider has one -- callers should
+// type-assert and fall back to a local embedding when it's missing.
+type EmbeddingProvider interface {
+	Embed(ctx context.Context, text string, opts Options) ([]float32, error)
+}
@@ -0,0 +1,21 @@
+package providers
+
+import "context"
+
+func init() {
+	Register(&mistralProvider{})
+}
+
+// mistralProvider talks to the Mistral API, which mirrors the OpenAI chat
+// completions wire format.
+type mistralProvider struct{}
+
+func (p *mistralProvider) Name() string { return "mistral" }
+
+func (p *mistralProvider) Generate(ctx context.Context, prompt string, opts Options) (string, error) {
+	return chatCompletionGenerate(ctx, "https://api.mistral.ai/v1/chat/completions", opts.APIKey, opts.Model, prompt)
+}
+
+func (p *mistralProvider) GenerateStream(ctx context.Context, prompt string, opts Options) (<-chan string, error) {
+	return chatCompletionStream(ctx, "https://api.mistral.ai/v1/chat/completions", opts.APIKey, opts.Model, prompt)
+}
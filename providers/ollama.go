@@ -0,0 +1,154 @@
+package providers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+func init() {
+	Register(&ollamaProvider{})
+}
+
+type ollamaProvider struct{}
+
+func (p *ollamaProvider) Name() string { return "ollama" }
+
+type ollamaRequest struct {
+	Model  string `json:"model"`
+	Prompt string `json:"prompt"`
+	Stream bool   `json:"stream"`
+}
+
+func (p *ollamaProvider) Generate(ctx context.Context, prompt string, opts Options) (string, error) {
+	var full strings.Builder
+	chunks, err := p.generateChunks(ctx, prompt, opts, false)
+	if err != nil {
+		return "", err
+	}
+	for chunk := range chunks {
+		full.WriteString(chunk)
+	}
+	return strings.TrimSpace(full.String()), nil
+}
+
+func (p *ollamaProvider) GenerateStream(ctx context.Context, prompt string, opts Options) (<-chan string, error) {
+	return p.generateChunks(ctx, prompt, opts, true)
+}
+
+// Embed implements EmbeddingProvider via Ollama's /api/embeddings endpoint
+// (e.g. opts.Model = "nomic-embed-text").
+func (p *ollamaProvider) Embed(ctx context.Context, text string, opts Options) ([]float32, error) {
+	ctx, cancel := context.WithTimeout(ctx, localRequestTimeout)
+	defer cancel()
+
+	jsonData, err := json.Marshal(map[string]string{"model": opts.Model, "prompt": text})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", opts.BaseURL+"/api/embeddings", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var embResp struct {
+		Embedding []float32 `json:"embedding"`
+	}
+	if err := json.Unmarshal(body, &embResp); err != nil {
+		return nil, err
+	}
+	if len(embResp.Embedding) == 0 {
+		return nil, fmt.Errorf("no embedding returned from Ollama")
+	}
+
+	return embResp.Embedding, nil
+}
+
+// generateChunks drives Ollama's NDJSON response format, where each line is
+// a JSON object with a "response" fragment and a "done" flag.
+func (p *ollamaProvider) generateChunks(ctx context.Context, prompt string, opts Options, stream bool) (<-chan string, error) {
+	ctx, cancel := context.WithTimeout(ctx, localRequestTimeout)
+
+	reqBody := ollamaRequest{
+		Model:  opts.Model,
+		Prompt: prompt,
+		Stream: stream,
+	}
+
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	url := opts.BaseURL + "/api/generate"
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	out := make(chan string)
+	go func() {
+		defer cancel()
+		defer resp.Body.Close()
+		defer close(out)
+
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			if ctx.Err() != nil {
+				return
+			}
+
+			var chunk struct {
+				Response string `json:"response"`
+				Done     bool   `json:"done"`
+				Error    string `json:"error"`
+			}
+			if err := json.Unmarshal(scanner.Bytes(), &chunk); err != nil {
+				continue
+			}
+			if chunk.Error != "" {
+				return
+			}
+			if chunk.Response != "" {
+				select {
+				case out <- chunk.Response:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if chunk.Done {
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
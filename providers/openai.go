@@ -0,0 +1,221 @@
+package providers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+func init() {
+	Register(&openAIProvider{})
+}
+
+// openAIProvider talks to the OpenAI chat completions API. Its request and
+// response shapes are reused by the generic OpenAI-compatible backends
+// (Groq, OpenRouter, Azure OpenAI) since they implement the same wire
+// format.
+type openAIProvider struct{}
+
+func (p *openAIProvider) Name() string { return "openai" }
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatRequest struct {
+	Model    string        `json:"model"`
+	Messages []chatMessage `json:"messages"`
+	Stream   bool          `json:"stream"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+}
+
+type chatStreamChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+	} `json:"choices"`
+}
+
+func (p *openAIProvider) Generate(ctx context.Context, prompt string, opts Options) (string, error) {
+	return chatCompletionGenerate(ctx, "https://api.openai.com/v1/chat/completions", opts.APIKey, opts.Model, prompt)
+}
+
+func (p *openAIProvider) GenerateStream(ctx context.Context, prompt string, opts Options) (<-chan string, error) {
+	return chatCompletionStream(ctx, "https://api.openai.com/v1/chat/completions", opts.APIKey, opts.Model, prompt)
+}
+
+// Embed implements EmbeddingProvider using OpenAI's embeddings endpoint
+// (e.g. opts.Model = "text-embedding-3-small").
+func (p *openAIProvider) Embed(ctx context.Context, text string, opts Options) ([]float32, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	jsonData, err := json.Marshal(map[string]string{"model": opts.Model, "input": text})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.openai.com/v1/embeddings", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+opts.APIKey)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var embResp struct {
+		Data []struct {
+			Embedding []float32 `json:"embedding"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &embResp); err != nil {
+		return nil, err
+	}
+	if len(embResp.Data) == 0 {
+		return nil, fmt.Errorf("no embedding returned from OpenAI")
+	}
+
+	return embResp.Data[0].Embedding, nil
+}
+
+// chatCompletionGenerate performs a non-streaming call against any backend
+// that implements the OpenAI chat completions wire format.
+func chatCompletionGenerate(ctx context.Context, url, apiKey, model, prompt string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	reqBody := chatRequest{
+		Model:    model,
+		Messages: []chatMessage{{Role: "user", Content: prompt}},
+	}
+
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	var chatResp chatResponse
+	if err := json.Unmarshal(body, &chatResp); err != nil {
+		return "", err
+	}
+
+	if len(chatResp.Choices) == 0 {
+		return "", fmt.Errorf("no response from %s", url)
+	}
+
+	return strings.TrimSpace(chatResp.Choices[0].Message.Content), nil
+}
+
+// chatCompletionStream performs a streaming call against any backend that
+// implements the OpenAI chat completions SSE format, emitting each delta as
+// it arrives.
+func chatCompletionStream(ctx context.Context, url, apiKey, model, prompt string) (<-chan string, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+
+	reqBody := chatRequest{
+		Model:    model,
+		Messages: []chatMessage{{Role: "user", Content: prompt}},
+		Stream:   true,
+	}
+
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+	if apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	out := make(chan string)
+	go func() {
+		defer cancel()
+		defer resp.Body.Close()
+		defer close(out)
+
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			if ctx.Err() != nil {
+				return
+			}
+
+			line := strings.TrimSpace(scanner.Text())
+			data, ok := strings.CutPrefix(line, "data: ")
+			if !ok || data == "[DONE]" {
+				continue
+			}
+
+			var chunk chatStreamChunk
+			if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+				continue
+			}
+			if len(chunk.Choices) > 0 && chunk.Choices[0].Delta.Content != "" {
+				select {
+				case out <- chunk.Choices[0].Delta.Content:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
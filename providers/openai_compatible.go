@@ -0,0 +1,125 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+func init() {
+	Register(&compatibleProvider{name: "groq", defaultBaseURL: "https://api.groq.com/openai/v1"})
+	Register(&compatibleProvider{name: "openrouter", defaultBaseURL: "https://openrouter.ai/api/v1"})
+	Register(&compatibleProvider{name: "azure-openai"})
+}
+
+// compatibleProvider covers any backend that speaks the OpenAI chat
+// completions wire format behind a different base URL: Groq and OpenRouter
+// default to a known base URL (Options.BaseURL overrides it), while Azure
+// OpenAI always requires the caller's own endpoint (it's deployment-scoped)
+// via Options.BaseURL.
+type compatibleProvider struct {
+	name           string
+	defaultBaseURL string
+}
+
+func (p *compatibleProvider) Name() string { return p.name }
+
+func (p *compatibleProvider) endpoint(opts Options) (string, error) {
+	base := opts.BaseURL
+	if base == "" {
+		base = p.defaultBaseURL
+	}
+	if base == "" {
+		return "", fmt.Errorf("%s requires a base_url in the config", p.name)
+	}
+	return strings.TrimRight(base, "/") + "/chat/completions", nil
+}
+
+func (p *compatibleProvider) Generate(ctx context.Context, prompt string, opts Options) (string, error) {
+	if p.name == "azure-openai" {
+		return p.azureGenerate(ctx, prompt, opts)
+	}
+
+	url, err := p.endpoint(opts)
+	if err != nil {
+		return "", err
+	}
+	return chatCompletionGenerate(ctx, url, opts.APIKey, opts.Model, prompt)
+}
+
+func (p *compatibleProvider) GenerateStream(ctx context.Context, prompt string, opts Options) (<-chan string, error) {
+	if p.name == "azure-openai" {
+		// Azure requests are made without streaming; the full response is
+		// delivered as a single chunk.
+		text, err := p.azureGenerate(ctx, prompt, opts)
+		if err != nil {
+			return nil, err
+		}
+		out := make(chan string, 1)
+		out <- text
+		close(out)
+		return out, nil
+	}
+
+	url, err := p.endpoint(opts)
+	if err != nil {
+		return nil, err
+	}
+	return chatCompletionStream(ctx, url, opts.APIKey, opts.Model, prompt)
+}
+
+// azureGenerate handles Azure OpenAI's auth (an "api-key" header instead of
+// a bearer token) and its deployment-scoped URL, which the user supplies in
+// full via BaseURL (e.g. ".../openai/deployments/<deployment>/chat/completions?api-version=...").
+func (p *compatibleProvider) azureGenerate(ctx context.Context, prompt string, opts Options) (string, error) {
+	if opts.BaseURL == "" {
+		return "", fmt.Errorf("azure-openai requires a base_url pointing at your deployment")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	reqBody := chatRequest{
+		Model:    opts.Model,
+		Messages: []chatMessage{{Role: "user", Content: prompt}},
+	}
+
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", opts.BaseURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("api-key", opts.APIKey)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	var chatResp chatResponse
+	if err := json.Unmarshal(body, &chatResp); err != nil {
+		return "", err
+	}
+
+	if len(chatResp.Choices) == 0 {
+		return "", fmt.Errorf("no response from Azure OpenAI")
+	}
+
+	return strings.TrimSpace(chatResp.Choices[0].Message.Content), nil
+}
@@ -0,0 +1,65 @@
+// Package providers implements the pluggable AI backends that easy-commits
+// can use to turn a git diff into a commit message. Adding a new backend
+// means adding a new file that registers itself via init() -- main.go never
+// needs to know the concrete provider names.
+package providers
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// Options carries the per-request parameters a Provider needs, independent
+// of how it was configured.
+type Options struct {
+	APIKey  string
+	Model   string
+	BaseURL string
+}
+
+// Provider is implemented by every AI backend easy-commits can talk to.
+// Generate blocks until the full response is available. GenerateStream
+// returns a channel of incremental chunks so callers can print progress
+// while the model is still responding; the channel is closed when the
+// response is complete or ctx is cancelled.
+type Provider interface {
+	Name() string
+	Generate(ctx context.Context, prompt string, opts Options) (string, error)
+	GenerateStream(ctx context.Context, prompt string, opts Options) (<-chan string, error)
+}
+
+// requestTimeout bounds how long a single call to a hosted backend may run,
+// layered on top of the caller's ctx (Ctrl-C cancellation) so a stalled
+// endpoint the user doesn't notice fails instead of hanging forever.
+const requestTimeout = 30 * time.Second
+
+// localRequestTimeout is the equivalent budget for Ollama, which runs
+// locally and is often slower per token than a hosted API.
+const localRequestTimeout = 60 * time.Second
+
+var registry = map[string]Provider{}
+
+// Register adds a Provider to the registry under its own Name(). Providers
+// call this from an init() function so importing the providers package is
+// enough to make them available.
+func Register(p Provider) {
+	registry[p.Name()] = p
+}
+
+// Get looks up a previously registered Provider by name.
+func Get(name string) (Provider, bool) {
+	p, ok := registry[name]
+	return p, ok
+}
+
+// Names returns the registered provider names, sorted for stable display in
+// prompts and error messages.
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
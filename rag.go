@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	ragindex "github.com/josiah-hester/easy-commits/index"
+	"github.com/josiah-hester/easy-commits/providers"
+)
+
+// defaultIndexedCommits caps how far back `easy-commits index` walks by
+// default; --count overrides it.
+const defaultIndexedCommits = 500
+
+// examplesRetrieved is how many similar historical commits get injected
+// into the prompt as few-shot examples.
+const examplesRetrieved = 3
+
+func handleIndex() {
+	if !isGitRepo() {
+		fmt.Println("Error: Not in a git repository")
+		return
+	}
+
+	config, err := loadConfig()
+	if err != nil {
+		fmt.Printf("Error loading config: %v\n", err)
+		fmt.Println("Run 'easy-commits config' to set up your AI provider")
+		return
+	}
+
+	count := defaultIndexedCommits
+	for i, arg := range os.Args {
+		if arg == "--count" && i+1 < len(os.Args) {
+			if n, err := strconv.Atoi(os.Args[i+1]); err == nil {
+				count = n
+			}
+		}
+	}
+
+	shas, err := recentCommitSHAs(count)
+	if err != nil {
+		fmt.Printf("Error reading git log: %v\n", err)
+		return
+	}
+
+	embed := embedFuncFor(config)
+	ctx := context.Background()
+
+	var examples []ragindex.Example
+	for i, sha := range shas {
+		message, summary, err := commitDiffSummary(sha)
+		if err != nil {
+			fmt.Printf("Skipping %s: %v\n", sha[:7], err)
+			continue
+		}
+
+		vector, err := embed(ctx, summary)
+		if err != nil {
+			fmt.Printf("Skipping %s: %v\n", sha[:7], err)
+			continue
+		}
+
+		examples = append(examples, ragindex.Example{Summary: summary, Message: message, Embedding: vector})
+		fmt.Printf("Indexed %d/%d commits\r", i+1, len(shas))
+	}
+	fmt.Println()
+
+	if err := ragindex.Save(examples); err != nil {
+		fmt.Printf("Error saving index: %v\n", err)
+		return
+	}
+
+	fmt.Printf("Indexed %d commits\n", len(examples))
+}
+
+// recentCommitSHAs returns up to n commit hashes, most recent first.
+func recentCommitSHAs(n int) ([]string, error) {
+	out, err := exec.Command("git", "log", fmt.Sprintf("-%d", n), "--format=%H").Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var shas []string
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if line != "" {
+			shas = append(shas, line)
+		}
+	}
+	return shas, nil
+}
+
+// commitDiffSummary returns a commit's message and a cheap, deterministic
+// summary of what it touched (its changed file list), which is what gets
+// embedded -- no model call needed just to build the index.
+func commitDiffSummary(sha string) (message, summary string, err error) {
+	msgOut, err := exec.Command("git", "show", "-s", "--format=%B", sha).Output()
+	if err != nil {
+		return "", "", err
+	}
+
+	filesOut, err := exec.Command("git", "diff-tree", "--no-commit-id", "--name-only", "-r", sha).Output()
+	if err != nil {
+		return "", "", err
+	}
+
+	return strings.TrimSpace(string(msgOut)), strings.TrimSpace(string(filesOut)), nil
+}
+
+// embedFuncFor picks the configured provider's embedding endpoint when
+// available, falling back to a local pure-Go embedding otherwise.
+func embedFuncFor(config *Config) ragindex.EmbedFunc {
+	if provider, ok := providers.Get(config.Provider); ok && config.EmbeddingModel != "" {
+		if embedder, ok := provider.(providers.EmbeddingProvider); ok {
+			opts := providers.Options{APIKey: config.APIKey, Model: config.EmbeddingModel, BaseURL: config.BaseURL}
+			return func(ctx context.Context, text string) ([]float32, error) {
+				return embedder.Embed(ctx, text, opts)
+			}
+		}
+	}
+
+	return func(ctx context.Context, text string) ([]float32, error) {
+		return ragindex.FallbackEmbed(text), nil
+	}
+}
+
+// retrieveExamples embeds the current diff's file list and returns the most
+// similar historical commits from the index, or nil if nothing has been
+// indexed yet.
+func retrieveExamples(ctx context.Context, config *Config, diff string) []ragindex.Example {
+	stored, err := ragindex.Load()
+	if err != nil || len(stored) == 0 {
+		return nil
+	}
+
+	embed := embedFuncFor(config)
+	query, err := embed(ctx, changedFilesSummary(diff))
+	if err != nil {
+		return nil
+	}
+
+	return ragindex.TopK(stored, query, examplesRetrieved)
+}
+
+// changedFilesSummary extracts just the file paths touched by a diff, the
+// same shape of text the index stores summaries as, so query and index
+// embeddings are comparable.
+func changedFilesSummary(diff string) string {
+	var files []string
+	for _, line := range strings.Split(diff, "\n") {
+		if path := filePathFromDiffLine(line); path != "" {
+			files = append(files, path)
+		}
+	}
+	if len(files) == 0 {
+		return diff
+	}
+	return strings.Join(files, "\n")
+}
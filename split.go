@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// runSplitCommit lets the user pick a subset of hunks at a time, generates a
+// commit message for just that subset, and repeats until every hunk has
+// been committed or skipped, or the user cancels. A skipped group is
+// unstaged and not offered again.
+func runSplitCommit(ctx context.Context, config *Config, diff, userContext string) error {
+	// diff is the diff of what's already staged, but applyPatchCached stages
+	// each selected hunk subset against HEAD -- unstage everything first so
+	// the index matches the pre-image each patch was built from, the same
+	// precondition `git add -p` relies on.
+	if err := unstageAll(); err != nil {
+		return fmt.Errorf("failed to unstage before splitting: %v", err)
+	}
+
+	remaining := parseHunks(diff)
+
+	for len(remaining) > 0 {
+		selected, err := selectHunks(remaining)
+		if err != nil {
+			return err
+		}
+		if len(selected) == 0 {
+			fmt.Println("Split commit cancelled")
+			return nil
+		}
+
+		patch := buildPatch(selected)
+		if err := applyPatchCached(patch); err != nil {
+			return fmt.Errorf("failed to stage selected hunks: %v", err)
+		}
+
+		message, err := generateCommitMessage(ctx, config, patch, userContext, true)
+		if err != nil {
+			return err
+		}
+
+		result, err := runReview(patch, message)
+		if err != nil {
+			return err
+		}
+
+		switch result.action {
+		case actionAccept:
+			if err := createCommit(result.message); err != nil {
+				return err
+			}
+			fmt.Println("Commit created successfully!")
+		case actionEdit:
+			edited, err := editInEditor(result.message)
+			if err != nil {
+				return err
+			}
+			if err := createCommit(edited); err != nil {
+				return err
+			}
+			fmt.Println("Commit created successfully!")
+		default:
+			fmt.Println("Skipped this group of hunks")
+			if err := unstageAll(); err != nil {
+				return fmt.Errorf("failed to unstage skipped hunks: %v", err)
+			}
+		}
+
+		remaining = remainingHunks(remaining, selected)
+	}
+
+	return nil
+}
+
+// applyPatchCached stages a standalone patch built from a subset of hunks,
+// the same mechanism `git add -p` uses under the hood.
+func applyPatchCached(patch string) error {
+	cmd := exec.Command("git", "apply", "--cached", "-")
+	cmd.Stdin = strings.NewReader(patch)
+	return cmd.Run()
+}
+
+// unstageAll resets the index back to HEAD without touching the working
+// tree, so the next applyPatchCached call has a clean base to apply against.
+func unstageAll() error {
+	return exec.Command("git", "reset").Run()
+}
+
+// remainingHunks returns the hunks from all that weren't part of selected.
+func remainingHunks(all, selected []*Hunk) []*Hunk {
+	chosen := make(map[*Hunk]bool, len(selected))
+	for _, h := range selected {
+		chosen[h] = true
+	}
+
+	var rest []*Hunk
+	for _, h := range all {
+		if !chosen[h] {
+			rest = append(rest, h)
+		}
+	}
+	return rest
+}
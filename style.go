@@ -0,0 +1,136 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// StyleProfile captures the conventions a repository's own history already
+// follows, learned by `easy-commits learn`, so prompts can be conditioned on
+// real project style instead of a generic template.
+type StyleProfile struct {
+	Types         []string       `json:"types"`
+	ScopeCounts   map[string]int `json:"scope_counts,omitempty"`
+	AvgSubjectLen int            `json:"avg_subject_len"`
+	UsesGitmoji   bool           `json:"uses_gitmoji"`
+}
+
+var gitmojiRe = regexp.MustCompile(`[\x{1F300}-\x{1FAFF}\x{2600}-\x{27BF}]`)
+
+func handleLearn() {
+	count := 200
+	for i, arg := range os.Args {
+		if arg == "--count" && i+1 < len(os.Args) {
+			if n, err := strconv.Atoi(os.Args[i+1]); err == nil {
+				count = n
+			}
+		}
+	}
+
+	if !isGitRepo() {
+		fmt.Println("Error: Not in a git repository")
+		return
+	}
+
+	messages, err := recentCommitMessages(count)
+	if err != nil {
+		fmt.Printf("Error reading git log: %v\n", err)
+		return
+	}
+	if len(messages) == 0 {
+		fmt.Println("No commit history to learn from")
+		return
+	}
+
+	profile := analyzeCommitStyle(messages)
+
+	config, err := loadConfig()
+	if err != nil {
+		config = &Config{}
+	}
+	config.StyleProfile = profile
+
+	if err := saveConfig(config); err != nil {
+		fmt.Printf("Error saving config: %v\n", err)
+		return
+	}
+
+	fmt.Printf("Learned style from %d commits:\n", len(messages))
+	fmt.Printf("  Types: %s\n", strings.Join(profile.Types, ", "))
+	fmt.Printf("  Average subject length: %d characters\n", profile.AvgSubjectLen)
+	fmt.Printf("  Uses gitmoji: %v\n", profile.UsesGitmoji)
+}
+
+// recentCommitMessages returns the full message body of the last n commits,
+// using a NUL separator since commit messages can contain anything else.
+func recentCommitMessages(n int) ([]string, error) {
+	cmd := exec.Command("git", "log", fmt.Sprintf("-%d", n), "--format=%B%x00")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []string
+	for _, msg := range strings.Split(string(output), "\x00") {
+		msg = strings.TrimSpace(msg)
+		if msg != "" {
+			messages = append(messages, msg)
+		}
+	}
+	return messages, nil
+}
+
+// analyzeCommitStyle extracts the type vocabulary, scope distribution,
+// average subject length and gitmoji usage from a set of commit messages.
+func analyzeCommitStyle(messages []string) *StyleProfile {
+	typeCounts := map[string]int{}
+	scopeCounts := map[string]int{}
+	totalSubjectLen := 0
+	gitmojiCount := 0
+
+	for _, msg := range messages {
+		subject := strings.SplitN(msg, "\n", 2)[0]
+		totalSubjectLen += len(subject)
+
+		if m := conventionalHeaderRe.FindStringSubmatch(subject); m != nil {
+			typeCounts[m[1]]++
+			if m[3] != "" {
+				scopeCounts[m[3]]++
+			}
+		}
+		if gitmojiRe.MatchString(subject) {
+			gitmojiCount++
+		}
+	}
+
+	types := make([]string, 0, len(typeCounts))
+	for t := range typeCounts {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return typeCounts[types[i]] > typeCounts[types[j]] })
+
+	return &StyleProfile{
+		Types:         types,
+		ScopeCounts:   scopeCounts,
+		AvgSubjectLen: totalSubjectLen / len(messages),
+		UsesGitmoji:   gitmojiCount*2 > len(messages),
+	}
+}
+
+// topScopes returns up to n scope names ordered by how often they appear.
+func topScopes(scopeCounts map[string]int, n int) []string {
+	scopes := make([]string, 0, len(scopeCounts))
+	for s := range scopeCounts {
+		scopes = append(scopes, s)
+	}
+	sort.Slice(scopes, func(i, j int) bool { return scopeCounts[scopes[i]] > scopeCounts[scopes[j]] })
+	if len(scopes) > n {
+		scopes = scopes[:n]
+	}
+	return scopes
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// ticketRe pulls a tracker reference out of a branch name: Jira-style
+// PROJ-123, or a bare GitHub/GitLab #123.
+var ticketRe = regexp.MustCompile(`[A-Z]+-\d+|#\d+`)
+
+// inferTicketFromBranch looks for a ticket ID in the current branch name,
+// e.g. "feature/PROJ-123-add-login" -> "PROJ-123".
+func inferTicketFromBranch() (string, bool) {
+	out, err := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD").Output()
+	if err != nil {
+		return "", false
+	}
+
+	branch := strings.TrimSpace(string(out))
+	ticket := ticketRe.FindString(branch)
+	return ticket, ticket != ""
+}
+
+// withTicketTrailer appends a "Refs: <ticket>" trailer to message, unless
+// the ticket is already mentioned somewhere in it.
+func withTicketTrailer(message, ticket string) string {
+	if ticket == "" || strings.Contains(message, ticket) {
+		return message
+	}
+	return strings.TrimRight(message, "\n") + fmt.Sprintf("\n\nRefs: %s", ticket)
+}
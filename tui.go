@@ -0,0 +1,193 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// reviewAction is the outcome the user picked in the review TUI.
+type reviewAction int
+
+const (
+	actionCancel reviewAction = iota
+	actionAccept
+	actionEdit
+	actionRegenerate
+	actionSplit
+)
+
+type reviewResult struct {
+	action  reviewAction
+	message string
+}
+
+var (
+	addedStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+	removedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+	hunkStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("6")).Bold(true)
+	helpStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	titleStyle   = lipgloss.NewStyle().Bold(true)
+)
+
+// highlightDiff colors +/- lines and hunk headers, vaguely mimicking what
+// `git diff --color` shows in a terminal.
+func highlightDiff(diff string) string {
+	lines := strings.Split(diff, "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "+") && !strings.HasPrefix(line, "+++"):
+			lines[i] = addedStyle.Render(line)
+		case strings.HasPrefix(line, "-") && !strings.HasPrefix(line, "---"):
+			lines[i] = removedStyle.Render(line)
+		case strings.HasPrefix(line, "@@"):
+			lines[i] = hunkStyle.Render(line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// reviewModel is the main interactive review screen: a read-only diff pane
+// on top and an editable commit message below it. Vi-like keys (j/k, g/G)
+// scroll the diff; i or enter switches focus to the message editor and esc
+// switches back.
+type reviewModel struct {
+	diffView viewport.Model
+	message  textarea.Model
+	editing  bool
+	result   reviewResult
+}
+
+func newReviewModel(diff, message string) reviewModel {
+	ta := textarea.New()
+	ta.SetValue(message)
+	ta.ShowLineNumbers = false
+	ta.SetWidth(80)
+	ta.SetHeight(6)
+
+	vp := viewport.New(80, 16)
+	vp.SetContent(highlightDiff(diff))
+
+	return reviewModel{diffView: vp, message: ta}
+}
+
+func (m reviewModel) Init() tea.Cmd { return nil }
+
+func (m reviewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.diffView.Width = msg.Width
+		m.diffView.Height = msg.Height - 12
+		m.message.SetWidth(msg.Width)
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.editing {
+			switch msg.String() {
+			case "esc":
+				m.editing = false
+				m.message.Blur()
+				return m, nil
+			}
+			var cmd tea.Cmd
+			m.message, cmd = m.message.Update(msg)
+			return m, cmd
+		}
+
+		switch msg.String() {
+		case "q", "ctrl+c":
+			m.result = reviewResult{action: actionCancel}
+			return m, tea.Quit
+		case "i", "enter":
+			m.editing = true
+			m.message.Focus()
+			return m, textarea.Blink
+		case "ctrl+s", "a":
+			m.result = reviewResult{action: actionAccept, message: m.message.Value()}
+			return m, tea.Quit
+		case "e":
+			m.result = reviewResult{action: actionEdit, message: m.message.Value()}
+			return m, tea.Quit
+		case "r":
+			m.result = reviewResult{action: actionRegenerate}
+			return m, tea.Quit
+		case "s":
+			m.result = reviewResult{action: actionSplit}
+			return m, tea.Quit
+		case "j", "down":
+			m.diffView.LineDown(1)
+		case "k", "up":
+			m.diffView.LineUp(1)
+		case "g":
+			m.diffView.GotoTop()
+		case "G":
+			m.diffView.GotoBottom()
+		}
+	}
+	return m, nil
+}
+
+func (m reviewModel) View() string {
+	help := helpStyle.Render("j/k scroll · i/enter edit message · a accept · e edit in $EDITOR · r regenerate · s split commit · q cancel")
+	return lipgloss.JoinVertical(lipgloss.Left,
+		titleStyle.Render("Diff"),
+		m.diffView.View(),
+		titleStyle.Render("Commit message"),
+		m.message.View(),
+		help,
+	)
+}
+
+// runReview drives the review TUI to completion and returns the user's
+// chosen action.
+func runReview(diff, message string) (reviewResult, error) {
+	p := tea.NewProgram(newReviewModel(diff, message))
+	final, err := p.Run()
+	if err != nil {
+		return reviewResult{}, err
+	}
+	return final.(reviewModel).result, nil
+}
+
+// editInEditor opens the user's $EDITOR (falling back to vi) on a scratch
+// file seeded with message and returns the edited contents.
+func editInEditor(message string) (string, error) {
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = "vi"
+	}
+
+	tmp, err := os.CreateTemp("", "easy-commits-*.msg")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.WriteString(message); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+
+	cmd := exec.Command(editor, tmp.Name())
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("failed to run editor: %v", err)
+	}
+
+	edited, err := os.ReadFile(tmp.Name())
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(edited)), nil
+}